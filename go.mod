@@ -21,6 +21,7 @@ require (
 	github.com/percona/percona-backup-mongodb v1.2.0
 	github.com/percona/pmgo v0.0.0-20171205120904-497d06e28f91
 	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_golang v1.5.1
 	github.com/robfig/cron/v3 v3.0.1
 	github.com/sirupsen/logrus v1.7.0
 	github.com/stretchr/testify v1.6.1
@@ -170,13 +170,62 @@ type ClusterCondition struct {
 	Message            string               `json:"message,omitempty"`
 }
 
+// PMMSpec configures the pmm-client sidecar.
+//
+// URIOptions are MongoDB connection string options. They are appended to the
+// connection string for crVersion < 1.2.0, translated into `pmm-admin add mongodb`
+// flags for crVersion >= 1.6.0 and ignored with a warning in between.
+// Supported options are tls, ssl, tlsInsecure, tlsAllowInvalidCertificates,
+// tlsCAFile, tlsCertificateKeyFile, authSource and authMechanism.
+//
+// MonitorUserKey and MonitorPasswordKey only change which users secret keys
+// pmm-client reads. The operator still creates and rotates the clusterMonitor user
+// from MONGODB_CLUSTER_MONITOR_USER and MONGODB_CLUSTER_MONITOR_PASSWORD,
+// so the custom keys must be kept in sync with them.
+//
+// ExportBackupMetrics exports the age and the result of the last finished backup
+// of the cluster as psmdb_backup_last_age_seconds and psmdb_backup_last_success
+// on the operator metrics endpoint (:8080/metrics).
 type PMMSpec struct {
-	Enabled      bool           `json:"enabled,omitempty"`
-	ServerHost   string         `json:"serverHost,omitempty"`
-	Image        string         `json:"image,omitempty"`
-	MongodParams string         `json:"mongodParams,omitempty"`
-	MongosParams string         `json:"mongosParams,omitempty"`
-	Resources    *ResourcesSpec `json:"resources,omitempty"`
+	Enabled                  bool                          `json:"enabled,omitempty"`
+	ServerHost               string                        `json:"serverHost,omitempty"`
+	Image                    string                        `json:"image,omitempty"`
+	ImagePullPolicy          corev1.PullPolicy             `json:"imagePullPolicy,omitempty"`
+	ImagePullSecrets         []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	MongodParams             string                        `json:"mongodParams,omitempty"`
+	MongosParams             string                        `json:"mongosParams,omitempty"`
+	URIOptions               map[string]string             `json:"uriOptions,omitempty"`
+	MonitorUserKey           string                        `json:"monitorUserKey,omitempty"`
+	MonitorPasswordKey       string                        `json:"monitorPasswordKey,omitempty"`
+	ListenPort               int32                         `json:"listenPort,omitempty"`
+	PortsMin                 int32                         `json:"portsMin,omitempty"`
+	PortsMax                 int32                         `json:"portsMax,omitempty"`
+	LivenessProbe            *corev1.Probe                 `json:"livenessProbe,omitempty"`
+	ReadinessProbe           *corev1.Probe                 `json:"readinessProbe,omitempty"`
+	Env                      []corev1.EnvVar               `json:"env,omitempty"`
+	ContainerSecurityContext *corev1.SecurityContext       `json:"containerSecurityContext,omitempty"`
+	CredentialsVolumeMount   *PMMCredentialsVolumeMount    `json:"credentialsVolumeMount,omitempty"`
+	PreStopCommand           []string                      `json:"preStopCommand,omitempty"`
+	Command                  []string                      `json:"command,omitempty"`
+	Args                     []string                      `json:"args,omitempty"`
+	ExportBackupMetrics      bool                          `json:"exportBackupMetrics,omitempty"`
+	Resources                *ResourcesSpec                `json:"resources,omitempty"`
+}
+
+// PMMCredentialsVolumeMount describes a volume with PMM server
+// credentials files to be mounted into the pmm-client container.
+// The mount path is exposed as PMM_CREDENTIALS_DIR, which pmm-agent itself
+// doesn't read: the files are only used by a custom pmm command/args.
+type PMMCredentialsVolumeMount struct {
+	// MountPath is the directory the credentials files are mounted at.
+	MountPath string `json:"mountPath,omitempty"`
+
+	// Secret represents a secret that should populate the volume.
+	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+
+	// Projected represents a projected volume with credentials files.
+	// It has precedence over Secret.
+	Projected *corev1.ProjectedVolumeSource `json:"projected,omitempty"`
 }
 
 type MultiAZ struct {
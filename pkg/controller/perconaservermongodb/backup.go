@@ -64,3 +64,20 @@ func (r *ReconcilePerconaServerMongoDB) reconcileBackupTasks(cr *api.PerconaServ
 
 	return nil
 }
+
+// reconcileBackupMetrics updates the exported metrics of the last finished backup
+func (r *ReconcilePerconaServerMongoDB) reconcileBackupMetrics(cr *api.PerconaServerMongoDB) error {
+	if !cr.Spec.PMM.ExportBackupMetrics {
+		r.backupMetrics.Update(cr, nil)
+		return nil
+	}
+
+	bcps := api.PerconaServerMongoDBBackupList{}
+	err := r.client.List(context.TODO(), &bcps, &client.ListOptions{Namespace: cr.Namespace})
+	if err != nil {
+		return fmt.Errorf("get backup list: %v", err)
+	}
+	r.backupMetrics.Update(cr, bcps.Items)
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package perconaservermongodb
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+	"github.com/percona/percona-server-mongodb-operator/pkg/psmdb"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// pmmAnnotationsKey lists the pod template annotations added by the operator
+// for pmm, so they can be removed later without touching the user defined ones
+const pmmAnnotationsKey = "percona.com/pmm-annotations"
+
+// addPMMSidecar adds the pmm-client container with its volumes and pull secrets
+// to the pod spec. Nothing is added if pmm is disabled or not fully configured.
+func (r *ReconcilePerconaServerMongoDB) addPMMSidecar(cr *api.PerconaServerMongoDB, spec *corev1.PodSpec, customAdminParams, replsetName string) error {
+	if !psmdb.PMMEnabled(cr.Spec.PMM) {
+		return nil
+	}
+
+	pmmsec := corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: usersSecretName, Namespace: cr.Namespace}, &pmmsec)
+	if err != nil {
+		return errors.Wrapf(err, "check pmm secrets: %s", usersSecretName)
+	}
+
+	pmmC, err := psmdb.AddPMMContainer(cr, usersSecretName, pmmsec, customAdminParams, replsetName)
+	if err != nil {
+		return errors.Wrap(err, "failed to create a pmm-client container")
+	}
+
+	spec.Containers = append(spec.Containers, pmmC)
+	spec.Volumes = append(spec.Volumes, psmdb.PMMVolumes(cr.Spec.PMM)...)
+	spec.ImagePullSecrets = mergePullSecrets(spec.ImagePullSecrets, psmdb.PMMImagePullSecrets(cr.Spec.PMM))
+
+	return nil
+}
+
+// pmmWarning returns the reason why the pmm config of the cluster
+// can't be applied as is or an empty string if there is none
+func pmmWarning(cr *api.PerconaServerMongoDB) string {
+	if cr.Spec.PMM.Enabled && !psmdb.PMMEnabled(cr.Spec.PMM) {
+		return "pmm is enabled but image or serverHost is empty, pmm-client won't be added"
+	}
+	if psmdb.PMMEnabled(cr.Spec.PMM) && len(cr.Spec.PMM.URIOptions) > 0 &&
+		cr.CompareVersion("1.2.0") >= 0 && cr.CompareVersion("1.6.0") < 0 {
+		return "pmm uriOptions are not supported for crVersion 1.2.0 - 1.5.x and will be ignored"
+	}
+
+	return ""
+}
+
+// logPMMWarning logs the pmm warning of the cluster only when it changes,
+// so a misconfigured CR doesn't flood the log on every reconcile
+func (r *ReconcilePerconaServerMongoDB) logPMMWarning(cr *api.PerconaServerMongoDB) {
+	key := cr.Namespace + "/" + cr.Name
+	msg := pmmWarning(cr)
+	if prev, ok := r.pmmWarnings.Load(key); ok && prev.(string) == msg {
+		return
+	}
+	r.pmmWarnings.Store(key, msg)
+
+	if msg != "" {
+		log.Info(msg, "Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
+	}
+}
+
+// pmmAnnotations returns the scrape annotations for pods with the pmm-client container
+func pmmAnnotations(cr *api.PerconaServerMongoDB) map[string]string {
+	if cr.CompareVersion("1.7.0") < 0 {
+		return nil
+	}
+
+	return psmdb.PMMAnnotations(cr.Spec.PMM)
+}
+
+// setPMMAnnotations removes the pmm annotations added to ann on previous
+// reconciles and adds pmmAnn instead. Keys present in userAnn are neither
+// removed nor overwritten.
+func setPMMAnnotations(ann, userAnn, pmmAnn map[string]string) {
+	if owned, ok := ann[pmmAnnotationsKey]; ok {
+		for _, k := range strings.Split(owned, ",") {
+			if v, ok := userAnn[k]; ok {
+				ann[k] = v
+				continue
+			}
+			delete(ann, k)
+		}
+		delete(ann, pmmAnnotationsKey)
+	}
+
+	added := make([]string, 0, len(pmmAnn))
+	for k, v := range pmmAnn {
+		if _, ok := userAnn[k]; ok {
+			continue
+		}
+		ann[k] = v
+		added = append(added, k)
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	sort.Strings(added)
+	ann[pmmAnnotationsKey] = strings.Join(added, ",")
+}
+
+func copyAnnotations(ann map[string]string) map[string]string {
+	c := make(map[string]string, len(ann))
+	for k, v := range ann {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,185 @@
+package perconaservermongodb
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// secretClient returns the given secret for any Get call
+type secretClient struct {
+	client.Client
+	secret corev1.Secret
+}
+
+func (c secretClient) Get(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+	c.secret.DeepCopyInto(obj.(*corev1.Secret))
+	return nil
+}
+
+func TestAddPMMSidecar(t *testing.T) {
+	usersSecretName = "users"
+	r := &ReconcilePerconaServerMongoDB{
+		client: secretClient{secret: corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "users"}}},
+	}
+
+	tests := map[string]struct {
+		pmm        api.PMMSpec
+		containers []string
+	}{
+		"enabled": {
+			pmm:        api.PMMSpec{Enabled: true, Image: "percona/pmm-client:2.12.0", ServerHost: "monitoring-service"},
+			containers: []string{"mongod", "pmm-client"},
+		},
+		"disabled": {
+			pmm:        api.PMMSpec{Image: "percona/pmm-client:2.12.0", ServerHost: "monitoring-service"},
+			containers: []string{"mongod"},
+		},
+		"empty image": {
+			pmm:        api.PMMSpec{Enabled: true, ServerHost: "monitoring-service"},
+			containers: []string{"mongod"},
+		},
+		"empty server host": {
+			pmm:        api.PMMSpec{Enabled: true, Image: "percona/pmm-client:2.12.0"},
+			containers: []string{"mongod"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &api.PerconaServerMongoDB{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+				Spec:       api.PerconaServerMongoDBSpec{CRVersion: "1.7.0", PMM: tt.pmm},
+			}
+			// the pod spec is rebuilt on every reconcile, so a sidecar
+			// which isn't added anymore is removed from the pods
+			spec := corev1.PodSpec{Containers: []corev1.Container{{Name: "mongod"}}}
+
+			assert.NoError(t, r.addPMMSidecar(cr, &spec, "", "rs0"))
+
+			names := []string{}
+			for _, c := range spec.Containers {
+				names = append(names, c.Name)
+			}
+			assert.Equal(t, tt.containers, names)
+		})
+	}
+}
+
+func TestPMMWarning(t *testing.T) {
+	cr := &api.PerconaServerMongoDB{
+		Spec: api.PerconaServerMongoDBSpec{
+			CRVersion: "1.7.0",
+			PMM:       api.PMMSpec{Image: "percona/pmm-client:2.12.0"},
+		},
+	}
+	assert.Empty(t, pmmWarning(cr))
+
+	cr.Spec.PMM.Enabled = true
+	assert.NotEmpty(t, pmmWarning(cr))
+
+	cr.Spec.PMM.ServerHost = "monitoring-service"
+	assert.Empty(t, pmmWarning(cr))
+
+	cr.Spec.PMM.URIOptions = map[string]string{"tls": "true"}
+	assert.Empty(t, pmmWarning(cr))
+
+	cr.Spec.CRVersion = "1.5.0"
+	assert.NotEmpty(t, pmmWarning(cr))
+
+	cr.Spec.CRVersion = "1.1.0"
+	assert.Empty(t, pmmWarning(cr))
+}
+
+func TestSetPMMAnnotations(t *testing.T) {
+	pmmAnn := map[string]string{
+		"prometheus.io/scrape": "true",
+		"prometheus.io/port":   "7777",
+		"prometheus.io/path":   "/debug/metrics",
+	}
+	userAnn := map[string]string{
+		"prometheus.io/scrape": "true",
+		"prometheus.io/port":   "9216",
+	}
+
+	tests := map[string]struct {
+		ann      map[string]string
+		userAnn  map[string]string
+		pmmAnn   map[string]string
+		expected map[string]string
+	}{
+		"pmm enabled": {
+			ann:    map[string]string{},
+			pmmAnn: pmmAnn,
+			expected: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "7777",
+				"prometheus.io/path":   "/debug/metrics",
+				pmmAnnotationsKey:      "prometheus.io/path,prometheus.io/port,prometheus.io/scrape",
+			},
+		},
+		"pmm disabled": {
+			ann: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "7777",
+				"prometheus.io/path":   "/debug/metrics",
+				pmmAnnotationsKey:      "prometheus.io/path,prometheus.io/port,prometheus.io/scrape",
+				"percona.com/ssl-hash": "abc",
+			},
+			expected: map[string]string{
+				"percona.com/ssl-hash": "abc",
+			},
+		},
+		"user annotations with pmm disabled": {
+			ann: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "9216",
+			},
+			userAnn: userAnn,
+			expected: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "9216",
+			},
+		},
+		"user annotations win over pmm": {
+			ann: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "9216",
+			},
+			userAnn: userAnn,
+			pmmAnn:  pmmAnn,
+			expected: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "9216",
+				"prometheus.io/path":   "/debug/metrics",
+				pmmAnnotationsKey:      "prometheus.io/path",
+			},
+		},
+		"user annotations added after pmm": {
+			ann: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "7777",
+				"prometheus.io/path":   "/debug/metrics",
+				pmmAnnotationsKey:      "prometheus.io/path,prometheus.io/port,prometheus.io/scrape",
+			},
+			userAnn: userAnn,
+			expected: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "9216",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			setPMMAnnotations(tt.ann, tt.userAnn, tt.pmmAnn)
+			assert.Equal(t, tt.expected, tt.ann)
+		})
+	}
+}
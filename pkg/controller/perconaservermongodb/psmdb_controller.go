@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -68,6 +69,11 @@ func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
 		return nil, fmt.Errorf("create clientcmd: %v", err)
 	}
 
+	bm := psmdb.NewBackupMetrics()
+	if err := metrics.Registry.Register(bm); err != nil {
+		return nil, fmt.Errorf("register backup metrics: %v", err)
+	}
+
 	return &ReconcilePerconaServerMongoDB{
 		client:        mgr.GetClient(),
 		scheme:        mgr.GetScheme(),
@@ -75,6 +81,8 @@ func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
 		reconcileIn:   time.Second * 5,
 		crons:         NewCronRegistry(),
 		lockers:       newLockStore(),
+		pmmWarnings:   new(sync.Map),
+		backupMetrics: bm,
 
 		clientcmd: cli,
 	}, nil
@@ -133,6 +141,10 @@ type ReconcilePerconaServerMongoDB struct {
 	reconcileIn   time.Duration
 
 	lockers lockStore
+
+	// pmmWarnings holds the last logged pmm warning per cluster
+	pmmWarnings   *sync.Map
+	backupMetrics *psmdb.BackupMetrics
 }
 
 type lockStore struct {
@@ -197,6 +209,7 @@ func (r *ReconcilePerconaServerMongoDB) Reconcile(request reconcile.Request) (re
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			r.backupMetrics.Delete(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -228,6 +241,8 @@ func (r *ReconcilePerconaServerMongoDB) Reconcile(request reconcile.Request) (re
 		usersSecretName = internalPrefix + cr.Name + "-users"
 	}
 
+	r.logPMMWarning(cr)
+
 	repls := cr.Spec.Replsets
 	if cr.Spec.Sharding.Enabled && cr.Spec.Sharding.ConfigsvrReplSet != nil {
 		repls = append(repls, cr.Spec.Sharding.ConfigsvrReplSet)
@@ -325,6 +340,10 @@ func (r *ReconcilePerconaServerMongoDB) Reconcile(request reconcile.Request) (re
 		}
 	}
 
+	if err := r.reconcileBackupMetrics(cr); err != nil {
+		reqLogger.Error(err, "failed to update backup metrics")
+	}
+
 	shards := 0
 	for _, replset := range repls {
 		if (cr.Spec.Sharding.Enabled && replset.ClusterRole == api.ClusterRoleShardSvr) ||
@@ -674,9 +693,8 @@ func (r *ReconcilePerconaServerMongoDB) reconcileMongos(cr *api.PerconaServerMon
 	if err != nil {
 		return errors.Wrap(err, "failed to get ssl annotations")
 	}
-	if deplSpec.Template.Annotations == nil {
-		deplSpec.Template.Annotations = make(map[string]string)
-	}
+	// don't modify the annotations map of the CR
+	deplSpec.Template.Annotations = copyAnnotations(deplSpec.Template.Annotations)
 	for k, v := range annotations {
 		deplSpec.Template.Annotations[k] = v
 	}
@@ -684,22 +702,11 @@ func (r *ReconcilePerconaServerMongoDB) reconcileMongos(cr *api.PerconaServerMon
 		deplSpec.Template.Annotations[k] = v
 	}
 
-	if cr.Spec.PMM.Enabled {
-		pmmsec := corev1.Secret{}
-		err := r.client.Get(context.TODO(), types.NamespacedName{Name: usersSecretName, Namespace: cr.Namespace}, &pmmsec)
-		if err != nil {
-			return errors.Wrapf(err, "check pmm secrets: %s", usersSecretName)
-		}
-
-		pmmC, err := psmdb.AddPMMContainer(cr, usersSecretName, pmmsec, cr.Spec.PMM.MongosParams)
-		if err != nil {
-			return errors.Wrap(err, "failed to create a pmm-client container")
-		}
-		deplSpec.Template.Spec.Containers = append(
-			deplSpec.Template.Spec.Containers,
-			pmmC,
-		)
+	err = r.addPMMSidecar(cr, &deplSpec.Template.Spec, cr.Spec.PMM.MongosParams, "")
+	if err != nil {
+		return err
 	}
+	setPMMAnnotations(deplSpec.Template.Annotations, cr.Spec.Sharding.Mongos.MultiAZ.Annotations, pmmAnnotations(cr))
 
 	msDepl.Spec = deplSpec
 	err = r.createOrUpdate(msDepl, msDepl.Name, msDepl.Namespace)
@@ -812,9 +819,8 @@ func (r *ReconcilePerconaServerMongoDB) reconcileStatefulSet(arbiter bool, cr *a
 	if err != nil {
 		return nil, fmt.Errorf("create StatefulSet.Spec %s: %v", sfs.Name, err)
 	}
-	if sfsSpec.Template.Annotations == nil {
-		sfsSpec.Template.Annotations = make(map[string]string)
-	}
+	// don't modify the annotations map of the CR
+	sfsSpec.Template.Annotations = copyAnnotations(sfsSpec.Template.Annotations)
 	for k, v := range sfs.Spec.Template.Annotations {
 		sfsSpec.Template.Annotations[k] = v
 	}
@@ -882,20 +888,18 @@ func (r *ReconcilePerconaServerMongoDB) reconcileStatefulSet(arbiter bool, cr *a
 			sfsSpec.Template.Spec.Containers = append(sfsSpec.Template.Spec.Containers, agentC)
 		}
 
-		if cr.Spec.PMM.Enabled {
-			pmmsec := corev1.Secret{}
-			err := r.client.Get(context.TODO(), types.NamespacedName{Name: usersSecretName, Namespace: cr.Namespace}, &pmmsec)
-			if err != nil {
-				return nil, fmt.Errorf("check pmm secrets: %v", err)
-			}
-			pmmC, err := psmdb.AddPMMContainer(cr, usersSecretName, pmmsec, cr.Spec.PMM.MongodParams)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create a pmm-client container: %v", err)
-			}
-			sfsSpec.Template.Spec.Containers = append(sfsSpec.Template.Spec.Containers, pmmC)
+		err = r.addPMMSidecar(cr, &sfsSpec.Template.Spec, cr.Spec.PMM.MongodParams, replset.Name)
+		if err != nil {
+			return nil, err
 		}
 	}
 
+	var pmmAnn map[string]string
+	if !arbiter {
+		pmmAnn = pmmAnnotations(cr)
+	}
+	setPMMAnnotations(sfsSpec.Template.Annotations, multiAZ.Annotations, pmmAnn)
+
 	switch cr.Spec.UpdateStrategy {
 	case appsv1.OnDeleteStatefulSetStrategyType:
 		sfsSpec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
@@ -1039,6 +1043,30 @@ func (r *ReconcilePerconaServerMongoDB) createOrUpdate(currentObj runtime.Object
 	return nil
 }
 
+// mergePullSecrets returns a new list with secrets from add
+// which aren't in secrets yet appended to it
+func mergePullSecrets(secrets, add []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	if len(add) == 0 {
+		return secrets
+	}
+
+	merged := append([]corev1.LocalObjectReference{}, secrets...)
+	for _, a := range add {
+		found := false
+		for _, s := range merged {
+			if s.Name == a.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, a)
+		}
+	}
+
+	return merged
+}
+
 func setControllerReference(owner runtime.Object, obj metav1.Object, scheme *runtime.Scheme) error {
 	ownerRef, err := OwnerRef(owner, scheme)
 	if err != nil {
@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+	"github.com/percona/percona-server-mongodb-operator/pkg/psmdb"
 	"github.com/percona/percona-server-mongodb-operator/pkg/psmdb/mongo"
 )
 
@@ -181,7 +182,7 @@ func (r *ReconcilePerconaServerMongoDB) updateSysUsers(cr *api.PerconaServerMong
 			needRestartSfs: true,
 		},
 	}
-	if cr.Spec.PMM.Enabled {
+	if psmdb.PMMEnabled(cr.Spec.PMM) {
 		// insert in front
 		users = append([]user{
 			{
@@ -0,0 +1,129 @@
+package psmdb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+)
+
+// BackupMetrics is a prometheus collector with the age and the result
+// of the last finished backup of clusters with pmm.exportBackupMetrics set
+type BackupMetrics struct {
+	mu   sync.Mutex
+	last map[string]lastBackup
+	now  func() time.Time
+
+	age     *prometheus.Desc
+	success *prometheus.Desc
+}
+
+type lastBackup struct {
+	namespace string
+	cluster   string
+	finished  time.Time
+	success   bool
+}
+
+// NewBackupMetrics returns a collector without any cluster metrics
+func NewBackupMetrics() *BackupMetrics {
+	labels := []string{"namespace", "cluster"}
+
+	return &BackupMetrics{
+		last: make(map[string]lastBackup),
+		now:  time.Now,
+		age: prometheus.NewDesc("psmdb_backup_last_age_seconds",
+			"Seconds since the last backup of the cluster has finished", labels, nil),
+		success: prometheus.NewDesc("psmdb_backup_last_success",
+			"Whether the last finished backup of the cluster has succeeded", labels, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (m *BackupMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.age
+	ch <- m.success
+}
+
+// Collect implements prometheus.Collector
+func (m *BackupMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for _, b := range m.last {
+		success := 0.0
+		if b.success {
+			success = 1
+		}
+		ch <- prometheus.MustNewConstMetric(m.age, prometheus.GaugeValue, now.Sub(b.finished).Seconds(), b.namespace, b.cluster)
+		ch <- prometheus.MustNewConstMetric(m.success, prometheus.GaugeValue, success, b.namespace, b.cluster)
+	}
+}
+
+// Update sets the metrics of the cluster from the last finished backup in bcps.
+// The metrics are removed if the export is disabled or no backup has finished yet.
+func (m *BackupMetrics) Update(cr *api.PerconaServerMongoDB, bcps []api.PerconaServerMongoDBBackup) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := cr.Namespace + "/" + cr.Name
+	if !PMMEnabled(cr.Spec.PMM) || !cr.Spec.PMM.ExportBackupMetrics {
+		delete(m.last, key)
+		return
+	}
+
+	last, ok := lastFinishedBackup(cr.Name, bcps)
+	if !ok {
+		delete(m.last, key)
+		return
+	}
+	last.namespace = cr.Namespace
+	last.cluster = cr.Name
+	m.last[key] = last
+}
+
+// Delete removes the metrics of the cluster
+func (m *BackupMetrics) Delete(namespace, cluster string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.last, namespace+"/"+cluster)
+}
+
+func lastFinishedBackup(cluster string, bcps []api.PerconaServerMongoDBBackup) (lastBackup, bool) {
+	var last lastBackup
+	found := false
+	for _, bcp := range bcps {
+		if bcp.Spec.PSMDBCluster != cluster {
+			continue
+		}
+		if bcp.Status.State != api.BackupStateReady && bcp.Status.State != api.BackupStateError {
+			continue
+		}
+
+		var finished *metav1.Time
+		switch {
+		case bcp.Status.CompletedAt != nil:
+			finished = bcp.Status.CompletedAt
+		case bcp.Status.LastTransition != nil:
+			finished = bcp.Status.LastTransition
+		default:
+			continue
+		}
+
+		if found && !finished.Time.After(last.finished) {
+			continue
+		}
+		last = lastBackup{
+			finished: finished.Time,
+			success:  bcp.Status.State == api.BackupStateReady,
+		}
+		found = true
+	}
+
+	return last, found
+}
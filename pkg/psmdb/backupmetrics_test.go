@@ -0,0 +1,71 @@
+package psmdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-server-mongodb-operator/pkg/apis/psmdb/v1"
+	"github.com/percona/percona-server-mongodb-operator/pkg/psmdb"
+)
+
+func TestBackupMetrics(t *testing.T) {
+	bm := psmdb.NewBackupMetrics()
+	reg := prometheus.NewRegistry()
+	assert.NoError(t, reg.Register(bm))
+
+	gather := func() map[string]float64 {
+		mfs, err := reg.Gather()
+		assert.NoError(t, err)
+		values := make(map[string]float64)
+		for _, mf := range mfs {
+			for _, m := range mf.GetMetric() {
+				values[mf.GetName()] = m.GetGauge().GetValue()
+			}
+		}
+		return values
+	}
+	backup := func(cluster string, state api.BackupState, finished time.Time) api.PerconaServerMongoDBBackup {
+		ts := metav1.NewTime(finished)
+		return api.PerconaServerMongoDBBackup{
+			Spec:   api.PerconaServerMongoDBBackupSpec{PSMDBCluster: cluster},
+			Status: api.PerconaServerMongoDBBackupStatus{State: state, LastTransition: &ts},
+		}
+	}
+
+	now := time.Now()
+	bcps := []api.PerconaServerMongoDBBackup{
+		backup("my-cluster", api.BackupStateReady, now.Add(-2*time.Hour)),
+		backup("my-cluster", api.BackupStateError, now.Add(-time.Hour)),
+		backup("my-cluster", api.BackupStateRunning, now),
+		backup("other-cluster", api.BackupStateReady, now),
+	}
+
+	cr := pmmCR("1.7.0", pmmSpec())
+	bm.Update(cr, bcps)
+	assert.Empty(t, gather())
+
+	cr.Spec.PMM.ExportBackupMetrics = true
+	bm.Update(cr, bcps)
+	values := gather()
+	assert.InDelta(t, time.Hour.Seconds(), values["psmdb_backup_last_age_seconds"], 60)
+	assert.Equal(t, 0.0, values["psmdb_backup_last_success"])
+
+	completed := metav1.NewTime(now.Add(-time.Minute))
+	bcps[0].Status.CompletedAt = &completed
+	bm.Update(cr, bcps)
+	values = gather()
+	assert.InDelta(t, time.Minute.Seconds(), values["psmdb_backup_last_age_seconds"], 60)
+	assert.Equal(t, 1.0, values["psmdb_backup_last_success"])
+
+	bm.Delete(cr.Namespace, cr.Name)
+	assert.Empty(t, gather())
+
+	bm.Update(cr, bcps)
+	cr.Spec.PMM.Enabled = false
+	bm.Update(cr, bcps)
+	assert.Empty(t, gather())
+}